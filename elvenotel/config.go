@@ -2,6 +2,7 @@ package elvenotel
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v10"
@@ -10,17 +11,42 @@ import (
 type Config struct {
 	ServiceName         string        `env:"OTEL_SERVICE_NAME" envDefault:"caio"`
 	OTLPEndpoint        string        `env:"OTEL_EXPORTER_OTLP_ENDPOINT" envDefault:"localhost:4317"`
+	OTLPProtocol        string        `env:"OTEL_EXPORTER_OTLP_PROTOCOL" envDefault:"grpc"`
+	OTLPLogsEndpoint    string        `env:"OTEL_EXPORTER_OTLP_LOGS_ENDPOINT"`
+	OTLPMetricsEndpoint string        `env:"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"`
+	OTLPTracesEndpoint  string        `env:"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"`
+	OTLPInsecure        bool          `env:"OTEL_EXPORTER_OTLP_INSECURE" envDefault:"false"`
+	OTLPCompression     string        `env:"OTEL_EXPORTER_OTLP_COMPRESSION"`
+	OTLPHeaders         string        `env:"OTEL_EXPORTER_OTLP_HEADERS"`
+	MetricsExporter     string        `env:"OTEL_METRICS_EXPORTER" envDefault:"otlp"`
 	ResourceAttributes  string        `env:"OTEL_RESOURCE_ATTRIBUTES"`
 	ServiceVersion      string        `env:"SERVICE_VERSION" envDefault:"0.0.1"`
 	Enabled             bool          `env:"TELEMETRY_ENABLED" envDefault:"true"`
 	OTELExporterTimeout time.Duration `env:"OTEL_EXPORTER_OTLP_TIMEOUT" envDefault:"10s"`
 	OTELLogLevel        string        `env:"OTEL_LOG_LEVEL" envDefault:"info"`
 	OTELPropagators     string        `env:"OTEL_PROPAGATORS" envDefault:"tracecontext,baggage"`
-	LokiAppName         string        `env:"LOKI_APP_NAME" envDefault:"go-app"`
-	LokiAuthToken       string        `env:"LOKI_AUTH_TOKEN"`
-	LokiFlushTimeout    int           `env:"LOKI_FLUSH_TIMEOUT" envDefault:"2000"`
-	LokiTenantID        string        `env:"LOKI_TENANT_ID" envDefault:"my-tenant"`
-	LokiURL             string        `env:"LOKI_URL"`
+	// OTELTracesSampler and OTELTracesSamplerArg select the trace sampler;
+	// see samplerFromConfig for the supported names and the meaning of the
+	// argument for each.
+	OTELTracesSampler    string `env:"OTEL_TRACES_SAMPLER" envDefault:"parentbased_always_on"`
+	OTELTracesSamplerArg string `env:"OTEL_TRACES_SAMPLER_ARG"`
+
+	// OTELBSPMaxQueueSize, OTELBSPScheduleDelay and OTELBSPMaxExportBatchSize
+	// configure the trace BatchSpanProcessor used by sdktrace.WithBatcher.
+	OTELBSPMaxQueueSize       int           `env:"OTEL_BSP_MAX_QUEUE_SIZE" envDefault:"2048"`
+	OTELBSPScheduleDelay      time.Duration `env:"OTEL_BSP_SCHEDULE_DELAY" envDefault:"5s"`
+	OTELBSPMaxExportBatchSize int           `env:"OTEL_BSP_MAX_EXPORT_BATCH_SIZE" envDefault:"512"`
+	// OTELMetricExportInterval configures the metric.PeriodicReader used by
+	// the metric exporter.
+	OTELMetricExportInterval time.Duration `env:"OTEL_METRIC_EXPORT_INTERVAL" envDefault:"60s"`
+	// OTELBLRPScheduleDelay configures the log BatchProcessor used by
+	// newLoggerProvider.
+	OTELBLRPScheduleDelay time.Duration `env:"OTEL_BLRP_SCHEDULE_DELAY" envDefault:"1s"`
+	LokiAppName           string        `env:"LOKI_APP_NAME" envDefault:"go-app"`
+	LokiAuthToken         string        `env:"LOKI_AUTH_TOKEN"`
+	LokiFlushTimeout      int           `env:"LOKI_FLUSH_TIMEOUT" envDefault:"2000"`
+	LokiTenantID          string        `env:"LOKI_TENANT_ID" envDefault:"my-tenant"`
+	LokiURL               string        `env:"LOKI_URL"`
 }
 
 func NewConfigFromEnv() (Config, error) {
@@ -30,3 +56,68 @@ func NewConfigFromEnv() (Config, error) {
 	}
 	return cfg, nil
 }
+
+// LogsEndpoint returns the endpoint used for the log exporter, falling back
+// to the shared OTLPEndpoint when OTEL_EXPORTER_OTLP_LOGS_ENDPOINT is unset.
+func (c Config) LogsEndpoint() string {
+	if c.OTLPLogsEndpoint != "" {
+		return c.OTLPLogsEndpoint
+	}
+	return c.OTLPEndpoint
+}
+
+// MetricsEndpoint returns the endpoint used for the metric exporter, falling
+// back to the shared OTLPEndpoint when OTEL_EXPORTER_OTLP_METRICS_ENDPOINT is unset.
+func (c Config) MetricsEndpoint() string {
+	if c.OTLPMetricsEndpoint != "" {
+		return c.OTLPMetricsEndpoint
+	}
+	return c.OTLPEndpoint
+}
+
+// TracesEndpoint returns the endpoint used for the trace exporter, falling
+// back to the shared OTLPEndpoint when OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is unset.
+func (c Config) TracesEndpoint() string {
+	if c.OTLPTracesEndpoint != "" {
+		return c.OTLPTracesEndpoint
+	}
+	return c.OTLPEndpoint
+}
+
+// Headers parses OTEL_EXPORTER_OTLP_HEADERS ("k1=v1,k2=v2") into a map,
+// trimming whitespace around keys and values. Malformed pairs are skipped.
+func (c Config) Headers() map[string]string {
+	return parseKVPairs(c.OTLPHeaders)
+}
+
+// ResourceAttributesMap parses OTEL_RESOURCE_ATTRIBUTES ("k1=v1,k2=v2") into
+// a map, trimming whitespace around keys and values. Malformed pairs are skipped.
+func (c Config) ResourceAttributesMap() map[string]string {
+	return parseKVPairs(c.ResourceAttributes)
+}
+
+// parseKVPairs parses a comma-separated list of "k=v" pairs into a map,
+// trimming whitespace around keys and values. Malformed pairs are skipped.
+func parseKVPairs(s string) map[string]string {
+	pairs := map[string]string{}
+	if s == "" {
+		return pairs
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+
+		key := strings.TrimSpace(kv[0])
+		value := strings.TrimSpace(kv[1])
+		if key == "" {
+			continue
+		}
+
+		pairs[key] = value
+	}
+
+	return pairs
+}
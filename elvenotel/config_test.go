@@ -0,0 +1,95 @@
+package elvenotel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfig_EndpointFallbacks(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"logs falls back to shared endpoint", Config{OTLPEndpoint: "shared:4317"}, "shared:4317"},
+		{"logs endpoint overrides shared", Config{OTLPEndpoint: "shared:4317", OTLPLogsEndpoint: "logs:4317"}, "logs:4317"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.LogsEndpoint(); got != tt.want {
+				t.Errorf("LogsEndpoint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	metricsTests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"metrics falls back to shared endpoint", Config{OTLPEndpoint: "shared:4317"}, "shared:4317"},
+		{"metrics endpoint overrides shared", Config{OTLPEndpoint: "shared:4317", OTLPMetricsEndpoint: "metrics:4317"}, "metrics:4317"},
+	}
+	for _, tt := range metricsTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.MetricsEndpoint(); got != tt.want {
+				t.Errorf("MetricsEndpoint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	tracesTests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"traces falls back to shared endpoint", Config{OTLPEndpoint: "shared:4317"}, "shared:4317"},
+		{"traces endpoint overrides shared", Config{OTLPEndpoint: "shared:4317", OTLPTracesEndpoint: "traces:4317"}, "traces:4317"},
+	}
+	for _, tt := range tracesTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.TracesEndpoint(); got != tt.want {
+				t.Errorf("TracesEndpoint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseKVPairs(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]string
+	}{
+		{"empty string", "", map[string]string{}},
+		{"single pair", "k1=v1", map[string]string{"k1": "v1"}},
+		{"multiple pairs", "k1=v1,k2=v2", map[string]string{"k1": "v1", "k2": "v2"}},
+		{"trims whitespace", " k1 = v1 , k2=v2", map[string]string{"k1": "v1", "k2": "v2"}},
+		{"skips malformed pair with no equals", "k1=v1,malformed,k2=v2", map[string]string{"k1": "v1", "k2": "v2"}},
+		{"skips pair with empty key", "=v1,k2=v2", map[string]string{"k2": "v2"}},
+		{"value may itself contain an equals sign", "k1=v1=extra", map[string]string{"k1": "v1=extra"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseKVPairs(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseKVPairs(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_HeadersAndResourceAttributesMap(t *testing.T) {
+	cfg := Config{
+		OTLPHeaders:        "x-api-key=secret",
+		ResourceAttributes: "env=prod,team=platform",
+	}
+
+	if got, want := cfg.Headers(), (map[string]string{"x-api-key": "secret"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("Headers() = %v, want %v", got, want)
+	}
+
+	if got, want := cfg.ResourceAttributesMap(), (map[string]string{"env": "prod", "team": "platform"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("ResourceAttributesMap() = %v, want %v", got, want)
+	}
+}
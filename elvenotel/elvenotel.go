@@ -2,12 +2,16 @@ package elvenotel
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 
+	"github.com/aws/aws-lambda-go/events"
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/contrib/bridges/otelzap"
 	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/sdk/metric"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -21,43 +25,68 @@ type TelemetryProvider interface {
 	LogInfo(args ...interface{})
 	LogErrorln(args ...interface{})
 	LogFatalln(args ...interface{})
+	LogInfow(ctx context.Context, msg string, keysAndValues ...interface{})
+	LogErrorw(ctx context.Context, msg string, keysAndValues ...interface{})
+	LogWarnw(ctx context.Context, msg string, keysAndValues ...interface{})
+	LogDebugw(ctx context.Context, msg string, keysAndValues ...interface{})
+	LoggerFromContext(ctx context.Context) *zap.SugaredLogger
 	MeterInt64Histogram(metric Metric) (otelmetric.Int64Histogram, error)
 	MeterInt64UpDownCounter(metric Metric) (otelmetric.Int64UpDownCounter, error)
 	TraceStart(ctx context.Context, name string) (context.Context, oteltrace.Span)
 	LogRequest() gin.HandlerFunc
 	MeterRequestDuration() gin.HandlerFunc
 	MeterRequestsInFlight() gin.HandlerFunc
-	Shutdown(ctx context.Context)
+	StartLambdaSpan(ctx context.Context, request events.APIGatewayProxyRequest) (context.Context, EndFunc)
+	StartSQSSpan(ctx context.Context, event events.SQSEvent) (context.Context, FinishFunc)
+	StartSNSSpan(ctx context.Context, event events.SNSEvent) (context.Context, FinishFunc)
+	StartEventBridgeSpan(ctx context.Context, event events.CloudWatchEvent) (context.Context, FinishFunc)
+	RecordMetrics(ctx context.Context) func()
+	ForceFlush(ctx context.Context) error
+	Shutdown(ctx context.Context) error
 }
 
 type Telemetry struct {
-	lp     *log.LoggerProvider
-	mp     *metric.MeterProvider
-	tp     *sdktrace.TracerProvider
-	log    *zap.SugaredLogger
-	meter  otelmetric.Meter
-	tracer oteltrace.Tracer
-	cfg    Config
+	lp          *log.LoggerProvider
+	mp          *metric.MeterProvider
+	tp          *sdktrace.TracerProvider
+	log         *zap.SugaredLogger
+	meter       otelmetric.Meter
+	tracer      oteltrace.Tracer
+	cfg         Config
+	promHandler http.Handler
+	lokiSink    *lokiSink
+
+	propagator propagation.TextMapPropagator
+	registry   *MetricsRegistry
 }
 
-func NewTelemetry(ctx context.Context, cfg Config) (*Telemetry, error) {
+// NewTelemetry creates a new telemetry instance. The built-in HTTP metrics
+// (see builtinMetrics) are always registered; metrics supplies any
+// additional instruments the caller wants pre-declared and cached in the
+// returned Telemetry's MetricsRegistry.
+func NewTelemetry(ctx context.Context, cfg Config, metrics ...Metric) (*Telemetry, error) {
 	lp, err := newLoggerProvider(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create logger provider: %w", err)
 	}
 
-	logger := zap.New(
-		zapcore.NewTee(
-			zapcore.NewCore(
-				zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
-				zapcore.AddSync(os.Stdout),
-				zapcore.InfoLevel,
-			),
-			otelzap.NewCore(cfg.ServiceName, otelzap.WithLoggerProvider(lp)),
+	cores := []zapcore.Core{
+		zapcore.NewCore(
+			zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+			zapcore.AddSync(os.Stdout),
+			parseLogLevel(cfg.OTELLogLevel),
 		),
-	)
+		otelzap.NewCore(cfg.ServiceName, otelzap.WithLoggerProvider(lp)),
+	}
 
-	mp, err := newMeterProvider(ctx, cfg)
+	lokiSink := newLokiSink(cfg)
+	if lokiCore := newLokiCore(lokiSink, zapcore.InfoLevel); lokiCore != nil {
+		cores = append(cores, lokiCore)
+	}
+
+	logger := zap.New(zapcore.NewTee(cores...))
+
+	mp, promHandler, err := newMeterProvider(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create meter provider: %w", err)
 	}
@@ -67,14 +96,25 @@ func NewTelemetry(ctx context.Context, cfg Config) (*Telemetry, error) {
 		return nil, fmt.Errorf("failed to create tracer provider: %w", err)
 	}
 
+	meter := mp.Meter(cfg.ServiceName)
+
+	registry, err := NewMetricsRegistry(meter, append(builtinMetrics, metrics...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build metrics registry: %w", err)
+	}
+
 	return &Telemetry{
-		lp:     lp,
-		mp:     mp,
-		tp:     tp,
-		log:    logger.Sugar(),
-		meter:  mp.Meter(cfg.ServiceName),
-		tracer: tp.Tracer(cfg.ServiceName),
-		cfg:    cfg,
+		lp:          lp,
+		mp:          mp,
+		tp:          tp,
+		log:         logger.Sugar(),
+		meter:       meter,
+		tracer:      tp.Tracer(cfg.ServiceName),
+		cfg:         cfg,
+		promHandler: promHandler,
+		lokiSink:    lokiSink,
+		propagator:  propagatorFromConfig(cfg),
+		registry:    registry,
 	}, nil
 }
 
@@ -82,6 +122,14 @@ func (t *Telemetry) GetServiceName() string {
 	return t.cfg.ServiceName
 }
 
+// PrometheusHandler returns the scrape handler for GET /metrics when
+// OTEL_METRICS_EXPORTER=prometheus is configured, and nil otherwise. Callers
+// typically mount it directly on the Gin router, e.g.
+// router.GET("/metrics", gin.WrapH(t.PrometheusHandler())).
+func (t *Telemetry) PrometheusHandler() http.Handler {
+	return t.promHandler
+}
+
 func (t *Telemetry) LogInfo(args ...interface{}) {
 	t.log.Info(args...)
 }
@@ -94,42 +142,133 @@ func (t *Telemetry) LogFatalln(args ...interface{}) {
 	t.log.Fatalln(args...)
 }
 
-func (t *Telemetry) MeterInt64Histogram(metric Metric) (otelmetric.Int64Histogram, error) {
-	histogram, err := t.meter.Int64Histogram(
-		metric.Name,
-		otelmetric.WithDescription(metric.Description),
-		otelmetric.WithUnit(metric.Unit),
+// LoggerFromContext returns a SugaredLogger with trace_id, span_id, and
+// trace_flags fields attached from the span stored in ctx, so log lines
+// emitted through it correlate with the trace in the collector. If ctx
+// carries no valid span it returns the base logger unchanged.
+func (t *Telemetry) LoggerFromContext(ctx context.Context) *zap.SugaredLogger {
+	spanCtx := oteltrace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return t.log
+	}
+
+	return t.log.With(
+		"trace_id", spanCtx.TraceID().String(),
+		"span_id", spanCtx.SpanID().String(),
+		"trace_flags", spanCtx.TraceFlags().String(),
 	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create histogram: %w", err)
+}
+
+// LogInfow logs a message at info level with structured key/value pairs,
+// tagged with the trace context carried by ctx.
+func (t *Telemetry) LogInfow(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	t.LoggerFromContext(ctx).Infow(msg, keysAndValues...)
+}
+
+// LogErrorw logs a message at error level with structured key/value pairs,
+// tagged with the trace context carried by ctx.
+func (t *Telemetry) LogErrorw(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	t.LoggerFromContext(ctx).Errorw(msg, keysAndValues...)
+}
+
+// LogWarnw logs a message at warn level with structured key/value pairs,
+// tagged with the trace context carried by ctx.
+func (t *Telemetry) LogWarnw(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	t.LoggerFromContext(ctx).Warnw(msg, keysAndValues...)
+}
+
+// LogDebugw logs a message at debug level with structured key/value pairs,
+// tagged with the trace context carried by ctx.
+func (t *Telemetry) LogDebugw(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	t.LoggerFromContext(ctx).Debugw(msg, keysAndValues...)
+}
+
+// parseLogLevel maps OTELLogLevel to a zapcore.Level, defaulting to info
+// when the value is empty or unrecognized.
+func parseLogLevel(level string) zapcore.Level {
+	var l zapcore.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return zapcore.InfoLevel
 	}
-	return histogram, nil
+
+	return l
 }
 
+// MeterInt64Histogram returns the registry's cached histogram for metric,
+// creating it on first use so repeated calls for the same name never
+// re-create the underlying OTel instrument.
+func (t *Telemetry) MeterInt64Histogram(metric Metric) (otelmetric.Int64Histogram, error) {
+	return t.registry.GetOrCreateInt64Histogram(metric)
+}
+
+// MeterInt64UpDownCounter returns the registry's cached up/down counter for
+// metric, creating it on first use so repeated calls for the same name
+// never re-create the underlying OTel instrument.
 func (t *Telemetry) MeterInt64UpDownCounter(metric Metric) (otelmetric.Int64UpDownCounter, error) {
-	counter, err := t.meter.Int64UpDownCounter(
-		metric.Name,
-		otelmetric.WithDescription(metric.Description),
-		otelmetric.WithUnit(metric.Unit),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create counter: %w", err)
-	}
-	return counter, nil
+	return t.registry.GetOrCreateInt64UpDownCounter(metric)
 }
 
 func (t *Telemetry) TraceStart(ctx context.Context, name string) (context.Context, oteltrace.Span) {
 	return t.tracer.Start(ctx, name)
 }
 
-func (t *Telemetry) Shutdown(ctx context.Context) {
-	if t.lp != nil {
-		_ = t.lp.Shutdown(ctx)
+// ForceFlush flushes any spans, logs and metrics buffered by the batch
+// span processor, log processor and periodic metric reader, returning the
+// joined error from all three. Short-lived processes (Lambda invocations,
+// CLIs) should call this before exiting so buffered telemetry isn't lost
+// to a process that dies before its next scheduled export.
+func (t *Telemetry) ForceFlush(ctx context.Context) error {
+	var errs []error
+
+	if t.tp != nil {
+		if err := t.tp.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to flush tracer provider: %w", err))
+		}
 	}
 	if t.mp != nil {
-		_ = t.mp.Shutdown(ctx)
+		if err := t.mp.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to flush meter provider: %w", err))
+		}
+	}
+	if t.lp != nil {
+		if err := t.lp.ForceFlush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to flush logger provider: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Shutdown force-flushes then shuts down the loki sink and the trace,
+// meter and logger providers, returning the joined error from every step
+// instead of swallowing it.
+func (t *Telemetry) Shutdown(ctx context.Context) error {
+	var errs []error
+
+	if err := t.ForceFlush(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	if t.lokiSink != nil {
+		if err := t.lokiSink.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close loki sink: %w", err))
+		}
 	}
 	if t.tp != nil {
-		_ = t.tp.Shutdown(ctx)
+		if err := t.tp.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to shut down tracer provider: %w", err))
+		}
+	}
+	if t.mp != nil {
+		if err := t.mp.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to shut down meter provider: %w", err))
+		}
 	}
+	if t.lp != nil {
+		if err := t.lp.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to shut down logger provider: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
 }
@@ -0,0 +1,38 @@
+package elvenotel
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestTelemetry_ForceFlushAndShutdown_NilProviders(t *testing.T) {
+	tel := &Telemetry{}
+	ctx := context.Background()
+
+	if err := tel.ForceFlush(ctx); err != nil {
+		t.Errorf("ForceFlush() on a Telemetry with no providers set error = %v, want nil", err)
+	}
+	if err := tel.Shutdown(ctx); err != nil {
+		t.Errorf("Shutdown() on a Telemetry with no providers set error = %v, want nil", err)
+	}
+}
+
+func TestTelemetry_ForceFlushAndShutdown_WithoutExporters(t *testing.T) {
+	tel := &Telemetry{
+		lp: log.NewLoggerProvider(),
+		mp: metric.NewMeterProvider(),
+		tp: sdktrace.NewTracerProvider(),
+	}
+	ctx := context.Background()
+
+	if err := tel.ForceFlush(ctx); err != nil {
+		t.Errorf("ForceFlush() error = %v, want nil for providers with no exporters", err)
+	}
+	if err := tel.Shutdown(ctx); err != nil {
+		t.Errorf("Shutdown() error = %v, want nil for providers with no exporters", err)
+	}
+}
@@ -0,0 +1,237 @@
+package elvenotel
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// EndFunc finalizes the span started by StartLambdaSpan. It records the
+// response status on the span and ends it; callers should defer it (or
+// call it directly before returning) with the response they send back to
+// API Gateway.
+type EndFunc func(response events.APIGatewayProxyResponse)
+
+// FinishFunc finalizes the span started by one of the async-trigger span
+// helpers (StartSQSSpan, StartSNSSpan, StartEventBridgeSpan). Pass the
+// handler's own return error so the span status reflects whether the
+// invocation succeeded.
+type FinishFunc func(err error)
+
+// coldStartOnce guards the process-lifetime cold-start flag: the first
+// invocation in a new execution environment is a cold start, every
+// subsequent invocation that reuses the same environment is not.
+var coldStartOnce sync.Once
+
+// isColdStart reports true exactly once per process, on the first call.
+func isColdStart() bool {
+	cold := false
+	coldStartOnce.Do(func() { cold = true })
+	return cold
+}
+
+// headerCarrier adapts the string-keyed header map used by API Gateway
+// events into the http.Header shape propagation.HeaderCarrier expects.
+func headerCarrier(headers map[string]string) propagation.HeaderCarrier {
+	h := make(http.Header, len(headers))
+	for k, v := range headers {
+		h.Set(k, v)
+	}
+	return propagation.HeaderCarrier(h)
+}
+
+// sqsMessageAttributeCarrier adapts an SQS message's MessageAttributes into
+// a propagation.TextMapCarrier so the configured propagator can extract the
+// trace context a producer attached to the message.
+type sqsMessageAttributeCarrier map[string]events.SQSMessageAttribute
+
+func (c sqsMessageAttributeCarrier) Get(key string) string {
+	if attr, ok := c[key]; ok && attr.StringValue != nil {
+		return *attr.StringValue
+	}
+	return ""
+}
+
+func (c sqsMessageAttributeCarrier) Set(string, string) {}
+
+func (c sqsMessageAttributeCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// snsMessageAttributeCarrier adapts an SNS notification's MessageAttributes
+// into a propagation.TextMapCarrier so the configured propagator can
+// extract the trace context a producer attached to the message. Unlike SQS,
+// aws-lambda-go decodes SNS message attributes into the untyped
+// map[string]interface{} shape SNS itself publishes as JSON
+// ({"Type": "String", "Value": "..."} per key), so each value needs a type
+// assertion rather than a typed struct field.
+type snsMessageAttributeCarrier map[string]interface{}
+
+func (c snsMessageAttributeCarrier) Get(key string) string {
+	attr, ok := c[key].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	value, _ := attr["Value"].(string)
+	return value
+}
+
+func (c snsMessageAttributeCarrier) Set(string, string) {}
+
+func (c snsMessageAttributeCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// StartLambdaSpan extracts any upstream trace context from request's
+// headers, starts a server span for the invocation, and tags it with FAAS
+// and HTTP semconv attributes. The returned EndFunc must be called with the
+// handler's response before it is returned to API Gateway.
+func (t *Telemetry) StartLambdaSpan(ctx context.Context, request events.APIGatewayProxyRequest) (context.Context, EndFunc) {
+	ctx = t.propagator.Extract(ctx, headerCarrier(request.Headers))
+
+	spanName := request.HTTPMethod + " " + request.Resource
+	ctx, span := t.tracer.Start(ctx, spanName, oteltrace.WithSpanKind(oteltrace.SpanKindServer))
+
+	span.SetAttributes(
+		attribute.String("faas.trigger", "http"),
+		attribute.String("faas.invocation_id", request.RequestContext.RequestID),
+		attribute.Bool("faas.coldstart", isColdStart()),
+		attribute.String("http.request.method", request.HTTPMethod),
+		attribute.String("url.path", request.Path),
+		attribute.String("http.route", request.Resource),
+		attribute.String("client.address", request.RequestContext.Identity.SourceIP),
+		attribute.String("user_agent.original", request.RequestContext.Identity.UserAgent),
+	)
+
+	return ctx, func(response events.APIGatewayProxyResponse) {
+		span.SetAttributes(attribute.Int("http.response.status_code", response.StatusCode))
+		if response.StatusCode >= 500 {
+			span.SetStatus(codes.Error, "")
+		}
+		span.End()
+	}
+}
+
+// startAsyncTriggerSpan is shared by the SQS/SNS/EventBridge span helpers:
+// they all start a consumer span tagged with the same FAAS attributes,
+// linked back to the trace context extracted from each message's
+// attributes, and finish it based on the handler's returned error rather
+// than an HTTP status code, since none of these triggers produce a
+// response.
+func (t *Telemetry) startAsyncTriggerSpan(ctx context.Context, trigger, spanName string, links []oteltrace.Link) (context.Context, FinishFunc) {
+	invocationID := ""
+	if lc, ok := lambdacontext.FromContext(ctx); ok {
+		invocationID = lc.AwsRequestID
+	}
+
+	opts := []oteltrace.SpanStartOption{oteltrace.WithSpanKind(oteltrace.SpanKindConsumer)}
+	if len(links) > 0 {
+		opts = append(opts, oteltrace.WithLinks(links...))
+	}
+
+	ctx, span := t.tracer.Start(ctx, spanName, opts...)
+
+	span.SetAttributes(
+		attribute.String("faas.trigger", trigger),
+		attribute.String("faas.invocation_id", invocationID),
+		attribute.Bool("faas.coldstart", isColdStart()),
+	)
+
+	return ctx, func(err error) {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// StartSQSSpan starts a consumer span for an SQS-triggered invocation,
+// linked to the trace context each record's MessageAttributes carries from
+// its producer.
+func (t *Telemetry) StartSQSSpan(ctx context.Context, event events.SQSEvent) (context.Context, FinishFunc) {
+	links := make([]oteltrace.Link, 0, len(event.Records))
+	for _, record := range event.Records {
+		msgCtx := t.propagator.Extract(ctx, sqsMessageAttributeCarrier(record.MessageAttributes))
+		if sc := oteltrace.SpanContextFromContext(msgCtx); sc.IsValid() {
+			links = append(links, oteltrace.Link{SpanContext: sc})
+		}
+	}
+
+	return t.startAsyncTriggerSpan(ctx, "pubsub", "SQS receive", links)
+}
+
+// StartSNSSpan starts a consumer span for an SNS-triggered invocation,
+// linked to the trace context each record's MessageAttributes carries from
+// its producer.
+func (t *Telemetry) StartSNSSpan(ctx context.Context, event events.SNSEvent) (context.Context, FinishFunc) {
+	links := make([]oteltrace.Link, 0, len(event.Records))
+	for _, record := range event.Records {
+		msgCtx := t.propagator.Extract(ctx, snsMessageAttributeCarrier(record.SNS.MessageAttributes))
+		if sc := oteltrace.SpanContextFromContext(msgCtx); sc.IsValid() {
+			links = append(links, oteltrace.Link{SpanContext: sc})
+		}
+	}
+
+	return t.startAsyncTriggerSpan(ctx, "pubsub", "SNS receive", links)
+}
+
+// StartEventBridgeSpan starts a consumer span for an EventBridge-triggered
+// invocation. EventBridge events are represented by events.CloudWatchEvent
+// in aws-lambda-go, which carries no standard trace-context carrier (it has
+// no message-attributes analogue), so the span it starts carries no links;
+// its parent is whatever span ctx already holds, if any.
+func (t *Telemetry) StartEventBridgeSpan(ctx context.Context, event events.CloudWatchEvent) (context.Context, FinishFunc) {
+	return t.startAsyncTriggerSpan(ctx, "pubsub", "EventBridge receive", nil)
+}
+
+// RecordMetrics records an invocation's duration (measured from start)
+// against MetricRequestDurationMillis and tracks MetricRequestsInFlight for
+// its lifetime. Callers typically defer the returned func at the top of a
+// handler.
+func (t *Telemetry) RecordMetrics(ctx context.Context) func() {
+	start := time.Now()
+
+	activeRequests, _ := t.registry.UpDownCounter(MetricRequestsInFlight.Name)
+	activeRequests.Add(ctx, 1)
+
+	return func() {
+		activeRequests.Add(ctx, -1)
+
+		duration, _ := t.registry.Histogram(MetricRequestDurationMillis.Name)
+		duration.Record(ctx, time.Since(start).Milliseconds())
+	}
+}
+
+// LambdaHandler wraps handler with span creation (StartLambdaSpan) and
+// metrics recording (RecordMetrics) so it can be passed directly to
+// lambda.Start for one-line instrumentation of an API Gateway handler. It
+// accepts the TelemetryProvider interface, so passing a NewNoopTelemetry
+// instance degrades to an uninstrumented pass-through, the same as the Gin
+// middlewares.
+func LambdaHandler(tp TelemetryProvider, handler func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)) func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		ctx, end := tp.StartLambdaSpan(ctx, request)
+		defer tp.RecordMetrics(ctx)()
+
+		response, err := handler(ctx, request)
+		end(response)
+
+		return response, err
+	}
+}
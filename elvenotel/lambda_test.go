@@ -0,0 +1,154 @@
+package elvenotel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newTestTelemetryForTracing builds a Telemetry backed by an in-memory span
+// exporter so tests can inspect the spans StartLambdaSpan/StartSQSSpan/
+// StartSNSSpan produce without a real OTLP collector.
+func newTestTelemetryForTracing(t *testing.T) (*Telemetry, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	return &Telemetry{
+		tracer:     tp.Tracer("test"),
+		propagator: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+	}, exporter
+}
+
+func attrValue(attrs []attribute.KeyValue, key string) (string, bool) {
+	for _, a := range attrs {
+		if string(a.Key) == key {
+			return a.Value.AsString(), true
+		}
+	}
+	return "", false
+}
+
+func TestTelemetry_StartLambdaSpan(t *testing.T) {
+	tel, exporter := newTestTelemetryForTracing(t)
+
+	request := events.APIGatewayProxyRequest{
+		HTTPMethod: "GET",
+		Path:       "/users/42",
+		Resource:   "/users/{id}",
+		RequestContext: events.APIGatewayProxyRequestContext{
+			RequestID: "req-1",
+			Identity: events.APIGatewayRequestIdentity{
+				SourceIP:  "203.0.113.1",
+				UserAgent: "test-agent",
+			},
+		},
+	}
+
+	_, end := tel.StartLambdaSpan(context.Background(), request)
+	end(events.APIGatewayProxyResponse{StatusCode: 500})
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+
+	if span.Status.Code != codes.Error {
+		t.Errorf("span status = %v, want Error for a 500 response", span.Status.Code)
+	}
+	if got, _ := attrValue(span.Attributes, "faas.invocation_id"); got != "req-1" {
+		t.Errorf("faas.invocation_id = %q, want %q", got, "req-1")
+	}
+	if got, _ := attrValue(span.Attributes, "client.address"); got != "203.0.113.1" {
+		t.Errorf("client.address = %q, want %q", got, "203.0.113.1")
+	}
+	if got, _ := attrValue(span.Attributes, "user_agent.original"); got != "test-agent" {
+		t.Errorf("user_agent.original = %q, want %q", got, "test-agent")
+	}
+}
+
+func TestTelemetry_StartSQSSpan_LinksProducerTrace(t *testing.T) {
+	tel, exporter := newTestTelemetryForTracing(t)
+
+	traceID := strings.Repeat("1", 32)
+	spanID := strings.Repeat("2", 16)
+	traceparent := fmt.Sprintf("00-%s-%s-01", traceID, spanID)
+
+	event := events.SQSEvent{
+		Records: []events.SQSMessage{
+			{
+				MessageAttributes: map[string]events.SQSMessageAttribute{
+					"traceparent": {DataType: "String", StringValue: &traceparent},
+				},
+			},
+		},
+	}
+
+	_, end := tel.StartSQSSpan(context.Background(), event)
+	end(nil)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if len(spans[0].Links) != 1 {
+		t.Fatalf("got %d links, want 1 linked back to the producer's trace", len(spans[0].Links))
+	}
+	if got := spans[0].Links[0].SpanContext.TraceID().String(); got != traceID {
+		t.Errorf("link trace ID = %s, want %s", got, traceID)
+	}
+}
+
+func TestTelemetry_StartSNSSpan(t *testing.T) {
+	tel, exporter := newTestTelemetryForTracing(t)
+
+	traceID := strings.Repeat("3", 32)
+	spanID := strings.Repeat("4", 16)
+	traceparent := fmt.Sprintf("00-%s-%s-01", traceID, spanID)
+
+	event := events.SNSEvent{
+		Records: []events.SNSEventRecord{
+			{
+				SNS: events.SNSEntity{
+					MessageAttributes: map[string]interface{}{
+						"traceparent": map[string]interface{}{
+							"Type":  "String",
+							"Value": traceparent,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, end := tel.StartSNSSpan(context.Background(), event)
+	end(errors.New("boom"))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+
+	if span.Status.Code != codes.Error {
+		t.Errorf("span status = %v, want Error when the handler returns an error", span.Status.Code)
+	}
+	if len(span.Links) != 1 {
+		t.Fatalf("got %d links, want 1 linked back to the producer's trace", len(span.Links))
+	}
+	if got := span.Links[0].SpanContext.TraceID().String(); got != traceID {
+		t.Errorf("link trace ID = %s, want %s", got, traceID)
+	}
+}
@@ -0,0 +1,76 @@
+package elvenotel
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newTestTelemetryForLogging(t *testing.T) (*Telemetry, *observer.ObservedLogs) {
+	t.Helper()
+	core, logs := observer.New(zapcore.InfoLevel)
+	return &Telemetry{log: zap.New(core).Sugar()}, logs
+}
+
+func TestTelemetry_LoggerFromContext_NoSpanReturnsBaseLogger(t *testing.T) {
+	tel, _ := newTestTelemetryForLogging(t)
+
+	if got := tel.LoggerFromContext(context.Background()); got != tel.log {
+		t.Error("LoggerFromContext() with no span in context should return the base logger unchanged")
+	}
+}
+
+func TestTelemetry_LogInfow_CorrelatesTraceFromContext(t *testing.T) {
+	tel, logs := newTestTelemetryForLogging(t)
+
+	tp := sdktrace.NewTracerProvider()
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+	ctx, span := tp.Tracer("test").Start(context.Background(), "op")
+	defer span.End()
+
+	spanCtx := oteltrace.SpanContextFromContext(ctx)
+	wantTraceID := spanCtx.TraceID().String()
+	wantSpanID := spanCtx.SpanID().String()
+
+	tel.LogInfow(ctx, "did the thing", "key", "value")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d log entries, want 1", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	if fields["trace_id"] != wantTraceID {
+		t.Errorf("trace_id = %v, want %v", fields["trace_id"], wantTraceID)
+	}
+	if fields["span_id"] != wantSpanID {
+		t.Errorf("span_id = %v, want %v", fields["span_id"], wantSpanID)
+	}
+	if fields["key"] != "value" {
+		t.Errorf("key = %v, want %v", fields["key"], "value")
+	}
+}
+
+func TestTelemetry_LogErrorw_NoSpanFallsBackCleanly(t *testing.T) {
+	tel, logs := newTestTelemetryForLogging(t)
+
+	tel.LogErrorw(context.Background(), "boom", "key", "value")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d log entries, want 1", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	if _, ok := fields["trace_id"]; ok {
+		t.Errorf("trace_id present without a span in context, want no correlation fields")
+	}
+	if fields["key"] != "value" {
+		t.Errorf("key = %v, want %v", fields["key"], "value")
+	}
+}
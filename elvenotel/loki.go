@@ -0,0 +1,229 @@
+package elvenotel
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// lokiEntry is a single buffered log line awaiting the next flush.
+type lokiEntry struct {
+	timestamp time.Time
+	level     string
+	line      string
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+// lokiSink batches log lines and POSTs them to a Loki push endpoint on a
+// fixed interval. It is shared by every zapcore.Core derived via With, so
+// all of them flush through the same buffer and HTTP client.
+type lokiSink struct {
+	url       string
+	tenantID  string
+	authToken string
+	labels    map[string]string
+	client    *http.Client
+
+	flushInterval time.Duration
+	done          chan struct{}
+	closeOnce     sync.Once
+
+	mu      sync.Mutex
+	entries []lokiEntry
+}
+
+// newLokiSink starts the periodic flush loop and returns the sink, or nil
+// when cfg.LokiURL is empty so the caller can skip teeing it in entirely.
+func newLokiSink(cfg Config) *lokiSink {
+	if cfg.LokiURL == "" {
+		return nil
+	}
+
+	flushInterval := time.Duration(cfg.LokiFlushTimeout) * time.Millisecond
+	if flushInterval <= 0 {
+		flushInterval = 2 * time.Second
+	}
+
+	labels := map[string]string{
+		"app":     cfg.LokiAppName,
+		"service": cfg.ServiceName,
+	}
+	for k, v := range cfg.ResourceAttributesMap() {
+		labels[k] = v
+	}
+
+	sink := &lokiSink{
+		url:           strings.TrimRight(cfg.LokiURL, "/") + "/loki/api/v1/push",
+		tenantID:      cfg.LokiTenantID,
+		authToken:     cfg.LokiAuthToken,
+		labels:        labels,
+		client:        &http.Client{Timeout: cfg.OTELExporterTimeout},
+		flushInterval: flushInterval,
+		done:          make(chan struct{}),
+	}
+
+	go sink.loop()
+
+	return sink
+}
+
+func (s *lokiSink) loop() {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = s.flush()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *lokiSink) add(timestamp time.Time, level, line string) {
+	s.mu.Lock()
+	s.entries = append(s.entries, lokiEntry{timestamp: timestamp, level: level, line: line})
+	s.mu.Unlock()
+}
+
+// flush ships any buffered entries to Loki, grouping them into one stream
+// per log level since every line in a stream must share the same labels.
+func (s *lokiSink) flush() error {
+	s.mu.Lock()
+	entries := s.entries
+	s.entries = nil
+	s.mu.Unlock()
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	byLevel := map[string][][2]string{}
+	for _, entry := range entries {
+		byLevel[entry.level] = append(byLevel[entry.level], [2]string{
+			strconv.FormatInt(entry.timestamp.UnixNano(), 10),
+			entry.line,
+		})
+	}
+
+	streams := make([]lokiStream, 0, len(byLevel))
+	for level, values := range byLevel {
+		stream := make(map[string]string, len(s.labels)+1)
+		for k, v := range s.labels {
+			stream[k] = v
+		}
+		stream["level"] = level
+
+		streams = append(streams, lokiStream{Stream: stream, Values: values})
+	}
+
+	body, err := json.Marshal(lokiPushRequest{Streams: streams})
+	if err != nil {
+		return fmt.Errorf("failed to marshal loki push request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build loki push request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.tenantID != "" {
+		req.Header.Set("X-Scope-OrgID", s.tenantID)
+	}
+	if s.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.authToken)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to push logs to loki: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("loki push to %s returned status %d", s.url, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Close stops the flush loop and ships any remaining buffered entries.
+func (s *lokiSink) Close() error {
+	s.closeOnce.Do(func() { close(s.done) })
+	return s.flush()
+}
+
+// lokiCore is a zapcore.Core that encodes log lines as JSON and hands them
+// off to a shared lokiSink for batched delivery.
+type lokiCore struct {
+	zapcore.LevelEnabler
+	encoder zapcore.Encoder
+	sink    *lokiSink
+}
+
+// newLokiCore wraps sink in a zapcore.Core, or returns nil when sink is nil.
+func newLokiCore(sink *lokiSink, enab zapcore.LevelEnabler) zapcore.Core {
+	if sink == nil {
+		return nil
+	}
+
+	return &lokiCore{
+		LevelEnabler: enab,
+		encoder:      zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		sink:         sink,
+	}
+}
+
+func (c *lokiCore) With(fields []zapcore.Field) zapcore.Core {
+	encoder := c.encoder.Clone()
+	for _, f := range fields {
+		f.AddTo(encoder)
+	}
+
+	return &lokiCore{
+		LevelEnabler: c.LevelEnabler,
+		encoder:      encoder,
+		sink:         c.sink,
+	}
+}
+
+func (c *lokiCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *lokiCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		return fmt.Errorf("failed to encode log entry for loki: %w", err)
+	}
+	line := buf.String()
+	buf.Free()
+
+	c.sink.add(ent.Time, ent.Level.String(), line)
+
+	return nil
+}
+
+func (c *lokiCore) Sync() error {
+	return c.sink.flush()
+}
@@ -0,0 +1,126 @@
+package elvenotel
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewLokiSink_NilWhenURLUnset(t *testing.T) {
+	if sink := newLokiSink(Config{}); sink != nil {
+		t.Fatalf("newLokiSink() with empty LokiURL = %v, want nil", sink)
+	}
+}
+
+func TestNewLokiSink_DefaultsFlushIntervalWhenNonPositive(t *testing.T) {
+	sink := newLokiSink(Config{LokiURL: "http://example.invalid", LokiFlushTimeout: 0})
+	t.Cleanup(func() { _ = sink.Close() })
+
+	if sink.flushInterval != 2*time.Second {
+		t.Fatalf("flushInterval = %v, want the 2s default for a non-positive LOKI_FLUSH_TIMEOUT", sink.flushInterval)
+	}
+}
+
+func TestLokiSink_Flush_NoEntriesIsANoop(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	sink := newLokiSink(Config{LokiURL: server.URL, LokiFlushTimeout: 60_000})
+	t.Cleanup(func() { _ = sink.Close() })
+
+	if err := sink.flush(); err != nil {
+		t.Fatalf("flush() with no buffered entries error = %v", err)
+	}
+	if called {
+		t.Fatal("flush() with no buffered entries made an HTTP request")
+	}
+}
+
+func TestLokiSink_Flush_GroupsEntriesByLevelAndSetsHeaders(t *testing.T) {
+	var gotReq lokiPushRequest
+	var gotTenant, gotAuth string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTenant = r.Header.Get("X-Scope-OrgID")
+		gotAuth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&gotReq); err != nil {
+			t.Errorf("failed to decode push request body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink := newLokiSink(Config{
+		LokiURL:          server.URL,
+		LokiFlushTimeout: 60_000,
+		LokiTenantID:     "tenant-1",
+		LokiAuthToken:    "token-1",
+		LokiAppName:      "my-app",
+		ServiceName:      "my-service",
+	})
+	t.Cleanup(func() { _ = sink.Close() })
+
+	sink.add(time.Unix(1, 0), "info", "first info line")
+	sink.add(time.Unix(2, 0), "info", "second info line")
+	sink.add(time.Unix(3, 0), "error", "an error line")
+
+	if err := sink.flush(); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+
+	if gotTenant != "tenant-1" {
+		t.Errorf("X-Scope-OrgID header = %q, want %q", gotTenant, "tenant-1")
+	}
+	if gotAuth != "Bearer token-1" {
+		t.Errorf("Authorization header = %q, want %q", gotAuth, "Bearer token-1")
+	}
+
+	if len(gotReq.Streams) != 2 {
+		t.Fatalf("got %d streams, want 2 (one per level)", len(gotReq.Streams))
+	}
+
+	byLevel := make(map[string]lokiStream, len(gotReq.Streams))
+	for _, stream := range gotReq.Streams {
+		byLevel[stream.Stream["level"]] = stream
+	}
+
+	info, ok := byLevel["info"]
+	if !ok {
+		t.Fatal("missing info stream")
+	}
+	if len(info.Values) != 2 {
+		t.Errorf("info stream has %d values, want 2", len(info.Values))
+	}
+	if info.Stream["app"] != "my-app" || info.Stream["service"] != "my-service" {
+		t.Errorf("info stream labels = %v, want app=my-app, service=my-service", info.Stream)
+	}
+
+	errLevel, ok := byLevel["error"]
+	if !ok {
+		t.Fatal("missing error stream")
+	}
+	if len(errLevel.Values) != 1 {
+		t.Errorf("error stream has %d values, want 1", len(errLevel.Values))
+	}
+}
+
+func TestLokiSink_Flush_NonSuccessStatusIsAnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sink := newLokiSink(Config{LokiURL: server.URL, LokiFlushTimeout: 60_000})
+	t.Cleanup(func() { _ = sink.Close() })
+
+	sink.add(time.Now(), "info", "line")
+
+	if err := sink.flush(); err == nil {
+		t.Fatal("flush() against a 500-returning server returned a nil error")
+	}
+}
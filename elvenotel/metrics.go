@@ -0,0 +1,61 @@
+package elvenotel
+
+// MetricKind identifies which OTel instrument a Metric definition backs.
+type MetricKind int
+
+const (
+	MetricKindInt64Histogram MetricKind = iota
+	MetricKindFloat64Histogram
+	MetricKindInt64UpDownCounter
+	MetricKindInt64Counter
+	MetricKindInt64Gauge
+)
+
+// Metric describes an OpenTelemetry instrument to be created by a
+// MetricsRegistry (or, for ad-hoc use, Telemetry.MeterInt64Histogram /
+// MeterInt64UpDownCounter).
+type Metric struct {
+	Name        string
+	Description string
+	Unit        string
+	Kind        MetricKind
+	// Boundaries are explicit histogram bucket boundaries. Only consulted
+	// for Kind == MetricKindFloat64Histogram; ignored otherwise.
+	Boundaries []float64
+}
+
+// Built-in metrics used by RecordMetrics (and so LambdaHandler);
+// MetricRequestsInFlight is also used by the MeterRequestsInFlight Gin
+// middleware.
+var (
+	MetricRequestsInFlight = Metric{
+		Name:        "http.server.active_requests",
+		Description: "Number of HTTP requests currently being served.",
+		Unit:        "{request}",
+		Kind:        MetricKindInt64UpDownCounter,
+	}
+	MetricRequestDurationMillis = Metric{
+		Name:        "http.server.duration",
+		Description: "Duration of HTTP requests.",
+		Unit:        "ms",
+		Kind:        MetricKindInt64Histogram,
+	}
+)
+
+// httpServerRequestDuration is the Gin middleware's own duration metric,
+// recorded in seconds with explicit semconv-style bucket boundaries.
+var httpServerRequestDuration = Metric{
+	Name:        "http.server.request.duration",
+	Description: "Duration of inbound HTTP requests.",
+	Unit:        "s",
+	Kind:        MetricKindFloat64Histogram,
+	Boundaries:  httpServerDurationBoundaries,
+}
+
+// builtinMetrics are always registered by NewTelemetry, in addition to any
+// caller-supplied metrics.
+var builtinMetrics = []Metric{
+	MetricRequestsInFlight,
+	MetricRequestDurationMillis,
+	httpServerRequestDuration,
+}
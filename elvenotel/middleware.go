@@ -0,0 +1,141 @@
+package elvenotel
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// httpServerDurationBoundaries are the explicit histogram bucket boundaries
+// (in seconds) for the http.server.request.duration metric, matching the
+// OTel semantic conventions for HTTP server metrics.
+var httpServerDurationBoundaries = []float64{
+	0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10,
+}
+
+// propagatorFromConfig builds a composite TextMapPropagator from the
+// comma-separated OTEL_PROPAGATORS list, defaulting to tracecontext+baggage
+// when the list is empty or contains no recognized propagator.
+func propagatorFromConfig(cfg Config) propagation.TextMapPropagator {
+	var propagators []propagation.TextMapPropagator
+
+	for _, name := range strings.Split(cfg.OTELPropagators, ",") {
+		switch strings.TrimSpace(strings.ToLower(name)) {
+		case "tracecontext":
+			propagators = append(propagators, propagation.TraceContext{})
+		case "baggage":
+			propagators = append(propagators, propagation.Baggage{})
+		case "b3":
+			propagators = append(propagators, b3.New())
+		}
+	}
+
+	if len(propagators) == 0 {
+		return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+	}
+
+	return propagation.NewCompositeTextMapPropagator(propagators...)
+}
+
+// routeOf returns the matched Gin route template, falling back to the raw
+// path when the route hasn't been matched (e.g. a 404).
+func routeOf(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return c.Request.URL.Path
+}
+
+// MeterRequestDuration extracts any upstream trace context from the
+// incoming request, starts a server span for it, and records its duration
+// into the http.server.request.duration histogram once the handler chain
+// completes. It must run before LogRequest for trace correlation to work.
+func (t *Telemetry) MeterRequestDuration() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := t.propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := routeOf(c)
+		spanName := c.Request.Method + " " + route
+
+		ctx, span := t.tracer.Start(ctx, spanName, oteltrace.WithSpanKind(oteltrace.SpanKindServer))
+		c.Request = c.Request.WithContext(ctx)
+
+		start := time.Now()
+
+		span.SetAttributes(
+			attribute.String("http.request.method", c.Request.Method),
+			attribute.String("url.path", c.Request.URL.Path),
+			attribute.String("http.route", route),
+			attribute.String("server.address", c.Request.Host),
+			attribute.String("user_agent.original", c.Request.UserAgent()),
+			attribute.String("client.address", c.ClientIP()),
+		)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		duration := time.Since(start).Seconds()
+
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, "")
+		}
+		span.End()
+
+		hist, _ := t.registry.Float64Histogram(httpServerRequestDuration.Name)
+		hist.Record(ctx, duration, otelmetric.WithAttributes(
+			attribute.String("method", c.Request.Method),
+			attribute.String("route", route),
+			attribute.Int("status_code", status),
+		))
+	}
+}
+
+// MeterRequestsInFlight maintains the http.server.active_requests up/down
+// counter for the lifetime of the handler chain.
+func (t *Telemetry) MeterRequestsInFlight() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		activeRequests, _ := t.registry.UpDownCounter(MetricRequestsInFlight.Name)
+		activeRequests.Add(ctx, 1)
+		defer activeRequests.Add(ctx, -1)
+
+		c.Next()
+	}
+}
+
+// LogRequest emits a single structured zap log line once the handler chain
+// completes, including the trace_id/span_id of the span started by
+// MeterRequestDuration so logs and traces correlate in the collector.
+func (t *Telemetry) LogRequest() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		span := oteltrace.SpanFromContext(c.Request.Context())
+		spanCtx := span.SpanContext()
+
+		fields := []interface{}{
+			"http.request.method", c.Request.Method,
+			"http.route", routeOf(c),
+			"url.path", c.Request.URL.Path,
+			"http.response.status_code", c.Writer.Status(),
+			"client.address", c.ClientIP(),
+		}
+		if spanCtx.IsValid() {
+			fields = append(fields,
+				"trace_id", spanCtx.TraceID().String(),
+				"span_id", spanCtx.SpanID().String(),
+			)
+		}
+
+		t.log.Infow("http request", fields...)
+	}
+}
@@ -0,0 +1,231 @@
+package elvenotel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newTestTelemetryForMiddleware builds a Telemetry backed by in-memory span,
+// metric and log sinks so the Gin middlewares can be exercised end to end
+// without a real collector.
+func newTestTelemetryForMiddleware(t *testing.T) (*Telemetry, *tracetest.InMemoryExporter, *metric.ManualReader, *observer.ObservedLogs) {
+	t.Helper()
+
+	spanExporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spanExporter))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	t.Cleanup(func() { _ = mp.Shutdown(context.Background()) })
+
+	registry, err := NewMetricsRegistry(mp.Meter("test"), builtinMetrics)
+	if err != nil {
+		t.Fatalf("NewMetricsRegistry() error = %v", err)
+	}
+
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	return &Telemetry{
+		tracer:     tp.Tracer("test"),
+		propagator: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+		registry:   registry,
+		log:        zap.New(core).Sugar(),
+	}, spanExporter, reader, logs
+}
+
+func TestPropagatorFromConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"defaults to tracecontext+baggage when unset", Config{}},
+		{"defaults to tracecontext+baggage when unrecognized", Config{OTELPropagators: "bogus"}},
+		{"tracecontext only", Config{OTELPropagators: "tracecontext"}},
+		{"baggage only", Config{OTELPropagators: "baggage"}},
+		{"b3", Config{OTELPropagators: "b3"}},
+		{"mixed case and whitespace", Config{OTELPropagators: " TraceContext , B3 "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			propagator := propagatorFromConfig(tt.cfg)
+			if propagator == nil {
+				t.Fatal("propagatorFromConfig() returned nil")
+			}
+			if len(propagator.Fields()) == 0 {
+				t.Error("propagatorFromConfig() returned a propagator with no fields")
+			}
+		})
+	}
+}
+
+func TestRouteOf(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("matched route", func(t *testing.T) {
+		router := gin.New()
+		var got string
+		router.GET("/users/:id", func(c *gin.Context) {
+			got = routeOf(c)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		if want := "/users/:id"; got != want {
+			t.Errorf("routeOf() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("unmatched route falls back to the raw path", func(t *testing.T) {
+		router := gin.New()
+		var got string
+		router.NoRoute(func(c *gin.Context) {
+			got = routeOf(c)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/does/not/exist", nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		if want := "/does/not/exist"; got != want {
+			t.Errorf("routeOf() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestTelemetry_MeterRequestDuration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tel, spanExporter, reader, _ := newTestTelemetryForMiddleware(t)
+
+	router := gin.New()
+	router.Use(tel.MeterRequestDuration())
+	router.GET("/users/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := spanExporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if want := "GET /users/:id"; spans[0].Name != want {
+		t.Errorf("span name = %q, want %q", spans[0].Name, want)
+	}
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	found := false
+	for _, sm := range data.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == httpServerRequestDuration.Name {
+				found = true
+			}
+		}
+	}
+	if !found {
+		t.Errorf("no %q data point recorded, want one per completed request", httpServerRequestDuration.Name)
+	}
+}
+
+func TestTelemetry_MeterRequestsInFlight(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tel, _, reader, _ := newTestTelemetryForMiddleware(t)
+
+	handlerCalled := false
+	router := gin.New()
+	router.Use(tel.MeterRequestsInFlight())
+	router.GET("/ping", func(c *gin.Context) {
+		handlerCalled = true
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !handlerCalled {
+		t.Fatal("MeterRequestsInFlight() did not call through to the downstream handler")
+	}
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	for _, sm := range data.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != MetricRequestsInFlight.Name {
+				continue
+			}
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if !ok {
+				t.Fatalf("%s has unexpected data type %T", m.Name, m.Data)
+			}
+			for _, dp := range sum.DataPoints {
+				if dp.Value != 0 {
+					t.Errorf("%s = %d after the request completed, want 0 (Add(1) balanced by the deferred Add(-1))", m.Name, dp.Value)
+				}
+			}
+		}
+	}
+}
+
+func TestTelemetry_LogRequest_CorrelatesWithSpan(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tel, _, _, logs := newTestTelemetryForMiddleware(t)
+
+	router := gin.New()
+	router.Use(tel.MeterRequestDuration(), tel.LogRequest())
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d log entries, want 1", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	if _, ok := fields["trace_id"]; !ok {
+		t.Error("log entry missing trace_id, want it correlated with the span MeterRequestDuration started")
+	}
+	if _, ok := fields["span_id"]; !ok {
+		t.Error("log entry missing span_id")
+	}
+}
+
+func TestTelemetry_LogRequest_NoSpanStillLogs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tel, _, _, logs := newTestTelemetryForMiddleware(t)
+
+	router := gin.New()
+	router.Use(tel.LogRequest())
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d log entries, want 1", len(entries))
+	}
+	if _, ok := entries[0].ContextMap()["trace_id"]; ok {
+		t.Error("log entry has trace_id without a span in context, want no trace correlation fields")
+	}
+}
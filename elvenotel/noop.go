@@ -4,23 +4,33 @@ import (
 	"context"
 	"os"
 
+	"github.com/aws/aws-lambda-go/events"
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
 type NoopTelemetry struct {
 	serviceName string
+	log         *zap.SugaredLogger
 }
 
 func NewNoopTelemetry(cfg Config) (*NoopTelemetry, error) {
-	return &NoopTelemetry{serviceName: cfg.ServiceName}, nil
+	return &NoopTelemetry{serviceName: cfg.ServiceName, log: zap.NewNop().Sugar()}, nil
 }
 
-func (t *NoopTelemetry) GetServiceName() string         { return t.serviceName }
-func (t *NoopTelemetry) LogInfo(args ...interface{})    {}
-func (t *NoopTelemetry) LogErrorln(args ...interface{}) {}
-func (t *NoopTelemetry) LogFatalln(args ...interface{}) { os.Exit(1) }
+func (t *NoopTelemetry) GetServiceName() string                                                  { return t.serviceName }
+func (t *NoopTelemetry) LogInfo(args ...interface{})                                             {}
+func (t *NoopTelemetry) LogErrorln(args ...interface{})                                          {}
+func (t *NoopTelemetry) LogFatalln(args ...interface{})                                          { os.Exit(1) }
+func (t *NoopTelemetry) LogInfow(ctx context.Context, msg string, keysAndValues ...interface{})  {}
+func (t *NoopTelemetry) LogErrorw(ctx context.Context, msg string, keysAndValues ...interface{}) {}
+func (t *NoopTelemetry) LogWarnw(ctx context.Context, msg string, keysAndValues ...interface{})  {}
+func (t *NoopTelemetry) LogDebugw(ctx context.Context, msg string, keysAndValues ...interface{}) {}
+func (t *NoopTelemetry) LoggerFromContext(ctx context.Context) *zap.SugaredLogger {
+	return t.log
+}
 func (t *NoopTelemetry) LogRequest() gin.HandlerFunc {
 	return func(c *gin.Context) { c.Next() }
 }
@@ -39,4 +49,18 @@ func (t *NoopTelemetry) MeterInt64Histogram(metric Metric) (metric.Int64Histogra
 func (t *NoopTelemetry) MeterInt64UpDownCounter(metric Metric) (metric.Int64UpDownCounter, error) {
 	return nil, nil
 }
-func (t *NoopTelemetry) Shutdown(ctx context.Context) {}
+func (t *NoopTelemetry) StartLambdaSpan(ctx context.Context, request events.APIGatewayProxyRequest) (context.Context, EndFunc) {
+	return ctx, func(events.APIGatewayProxyResponse) {}
+}
+func (t *NoopTelemetry) StartSQSSpan(ctx context.Context, event events.SQSEvent) (context.Context, FinishFunc) {
+	return ctx, func(error) {}
+}
+func (t *NoopTelemetry) StartSNSSpan(ctx context.Context, event events.SNSEvent) (context.Context, FinishFunc) {
+	return ctx, func(error) {}
+}
+func (t *NoopTelemetry) StartEventBridgeSpan(ctx context.Context, event events.CloudWatchEvent) (context.Context, FinishFunc) {
+	return ctx, func(error) {}
+}
+func (t *NoopTelemetry) RecordMetrics(ctx context.Context) func() { return func() {} }
+func (t *NoopTelemetry) ForceFlush(ctx context.Context) error     { return nil }
+func (t *NoopTelemetry) Shutdown(ctx context.Context) error       { return nil }
@@ -3,35 +3,178 @@ package elvenotel
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
 	"go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
-	"go.opentelemetry.io/otel/sdk/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 )
 
+// isHTTPProtocol reports whether cfg selects the http/protobuf OTLP
+// transport instead of the grpc default.
+func isHTTPProtocol(cfg Config) bool {
+	return strings.EqualFold(cfg.OTLPProtocol, "http/protobuf") || strings.EqualFold(cfg.OTLPProtocol, "http")
+}
+
+// newLogExporter builds the OTLP log exporter for the protocol selected in cfg.
+func newLogExporter(ctx context.Context, cfg Config) (log.Exporter, error) {
+	if isHTTPProtocol(cfg) {
+		opts := []otlploghttp.Option{
+			otlploghttp.WithEndpoint(cfg.LogsEndpoint()),
+			otlploghttp.WithTimeout(cfg.OTELExporterTimeout),
+			otlploghttp.WithHeaders(cfg.Headers()),
+		}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlploghttp.WithInsecure())
+		}
+		if cfg.OTLPCompression == "gzip" {
+			opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+		}
+
+		return otlploghttp.New(ctx, opts...)
+	}
+
+	opts := []otlploggrpc.Option{
+		otlploggrpc.WithEndpoint(cfg.LogsEndpoint()),
+		otlploggrpc.WithTimeout(cfg.OTELExporterTimeout),
+		otlploggrpc.WithHeaders(cfg.Headers()),
+	}
+	if cfg.OTLPInsecure {
+		opts = append(opts, otlploggrpc.WithInsecure())
+	}
+	if cfg.OTLPCompression == "gzip" {
+		opts = append(opts, otlploggrpc.WithCompressor(cfg.OTLPCompression))
+	}
+
+	return otlploggrpc.New(ctx, opts...)
+}
+
+// newTraceExporter builds the OTLP trace exporter for the protocol selected in cfg.
+func newTraceExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	if isHTTPProtocol(cfg) {
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(cfg.TracesEndpoint()),
+			otlptracehttp.WithTimeout(cfg.OTELExporterTimeout),
+			otlptracehttp.WithHeaders(cfg.Headers()),
+		}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if cfg.OTLPCompression == "gzip" {
+			opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+		}
+
+		return otlptracehttp.New(ctx, opts...)
+	}
+
+	opts := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(cfg.TracesEndpoint()),
+		otlptracegrpc.WithTimeout(cfg.OTELExporterTimeout),
+		otlptracegrpc.WithHeaders(cfg.Headers()),
+	}
+	if cfg.OTLPInsecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	if cfg.OTLPCompression == "gzip" {
+		opts = append(opts, otlptracegrpc.WithCompressor(cfg.OTLPCompression))
+	}
+
+	return otlptracegrpc.New(ctx, opts...)
+}
+
+// newMetricReader builds the metric.Reader selected by cfg.MetricsExporter,
+// falling back to an OTLP periodic reader for the "otlp" (default) case.
+// For "prometheus" it also returns a scrape http.Handler that the caller can
+// mount (e.g. on the Gin server) to serve GET /metrics; it is nil otherwise.
+func newMetricReader(ctx context.Context, cfg Config) (metric.Reader, http.Handler, error) {
+	switch strings.ToLower(cfg.MetricsExporter) {
+	case "stdout":
+		exporter, err := stdoutmetric.New()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create stdout metric exporter: %w", err)
+		}
+
+		return metric.NewPeriodicReader(exporter, metric.WithInterval(cfg.OTELMetricExportInterval)), nil, nil
+	case "prometheus":
+		registry := prometheus.NewRegistry()
+
+		reader, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
+		}
+
+		handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+
+		return reader, handler, nil
+	case "", "otlp":
+		exporter, err := newOTLPMetricExporter(ctx, cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return metric.NewPeriodicReader(exporter, metric.WithInterval(cfg.OTELMetricExportInterval)), nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported OTEL_METRICS_EXPORTER %q", cfg.MetricsExporter)
+	}
+}
+
+func newOTLPMetricExporter(ctx context.Context, cfg Config) (metric.Exporter, error) {
+	if isHTTPProtocol(cfg) {
+		opts := []otlpmetrichttp.Option{
+			otlpmetrichttp.WithEndpoint(cfg.MetricsEndpoint()),
+			otlpmetrichttp.WithTimeout(cfg.OTELExporterTimeout),
+			otlpmetrichttp.WithHeaders(cfg.Headers()),
+		}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		if cfg.OTLPCompression == "gzip" {
+			opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+		}
+
+		return otlpmetrichttp.New(ctx, opts...)
+	}
+
+	opts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(cfg.MetricsEndpoint()),
+		otlpmetricgrpc.WithTimeout(cfg.OTELExporterTimeout),
+		otlpmetricgrpc.WithHeaders(cfg.Headers()),
+	}
+	if cfg.OTLPInsecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+	if cfg.OTLPCompression == "gzip" {
+		opts = append(opts, otlpmetricgrpc.WithCompressor(cfg.OTLPCompression))
+	}
+
+	return otlpmetricgrpc.New(ctx, opts...)
+}
+
 // newLoggerProvider cria um logger provider com suporte a exportação
 func newLoggerProvider(ctx context.Context, cfg Config) (*log.LoggerProvider, error) {
 	res := newResource(cfg.ServiceName, cfg.ServiceVersion)
 
-	// Cria o exportador OTLP com configurações adequadas
-	exporter, err := otlploggrpc.New(
-		ctx,
-		otlploggrpc.WithEndpoint(cfg.OTLPEndpoint),
-		otlploggrpc.WithTimeout(cfg.OTELExporterTimeout),
-	)
+	exporter, err := newLogExporter(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create OTLP log exporter: %w", err)
 	}
 
-	// Configura o processador em lote
-	processor := log.NewBatchProcessor(exporter)
+	processor := log.NewBatchProcessor(exporter, log.WithExportInterval(cfg.OTELBLRPScheduleDelay))
 
 	lp := log.NewLoggerProvider(
 		log.WithProcessor(processor),
@@ -41,46 +184,48 @@ func newLoggerProvider(ctx context.Context, cfg Config) (*log.LoggerProvider, er
 	return lp, nil
 }
 
-// newMeterProvider cria um meter provider com suporte a exportação
-func newMeterProvider(ctx context.Context, cfg Config) (*metric.MeterProvider, error) {
+// newMeterProvider cria um meter provider com suporte a exportação. The
+// returned http.Handler is non-nil only when cfg.MetricsExporter selects the
+// prometheus pull exporter.
+func newMeterProvider(ctx context.Context, cfg Config) (*metric.MeterProvider, http.Handler, error) {
 	res := newResource(cfg.ServiceName, cfg.ServiceVersion)
 
-	// Cria o exportador OTLP com configurações adequadas
-	exporter, err := otlpmetricgrpc.New(
-		ctx,
-		otlpmetricgrpc.WithEndpoint(cfg.OTLPEndpoint),
-		otlpmetricgrpc.WithTimeout(cfg.OTELExporterTimeout),
-	)
+	reader, promHandler, err := newMetricReader(ctx, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+		return nil, nil, fmt.Errorf("failed to create metric reader: %w", err)
 	}
 
 	mp := metric.NewMeterProvider(
-		metric.WithReader(metric.NewPeriodicReader(exporter)),
+		metric.WithReader(reader),
 		metric.WithResource(res),
 	)
 	otel.SetMeterProvider(mp)
 
-	return mp, nil
+	return mp, promHandler, nil
 }
 
 // newTracerProvider cria um tracer provider com suporte a exportação
-func newTracerProvider(ctx context.Context, cfg Config) (*trace.TracerProvider, error) {
+func newTracerProvider(ctx context.Context, cfg Config) (*sdktrace.TracerProvider, error) {
 	res := newResource(cfg.ServiceName, cfg.ServiceVersion)
 
-	// Cria o exportador OTLP com configurações adequadas
-	exporter, err := otlptracegrpc.New(
-		ctx,
-		otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint),
-		otlptracegrpc.WithTimeout(cfg.OTELExporterTimeout),
-	)
+	exporter, err := newTraceExporter(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
 	}
 
-	tp := trace.NewTracerProvider(
-		trace.WithBatcher(exporter),
-		trace.WithResource(res),
+	sampler, err := samplerFromConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace sampler: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter,
+			sdktrace.WithMaxQueueSize(cfg.OTELBSPMaxQueueSize),
+			sdktrace.WithBatchTimeout(cfg.OTELBSPScheduleDelay),
+			sdktrace.WithMaxExportBatchSize(cfg.OTELBSPMaxExportBatchSize),
+		),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sampler),
 	)
 	otel.SetTracerProvider(tp)
 
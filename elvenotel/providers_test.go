@@ -0,0 +1,90 @@
+package elvenotel
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewMetricReader_DispatchesByExporter(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		exporter        string
+		wantErr         bool
+		wantHTTPHandler bool
+	}{
+		{"stdout exporter", "stdout", false, false},
+		{"prometheus exporter", "prometheus", false, true},
+		{"unset defaults to otlp", "", false, false},
+		{"explicit otlp", "otlp", false, false},
+		{"unsupported exporter is an error", "bogus", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{MetricsExporter: tt.exporter, OTLPEndpoint: "localhost:4317"}
+
+			reader, handler, err := newMetricReader(ctx, cfg)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("newMetricReader() error = nil, want non-nil for an unsupported exporter")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newMetricReader() error = %v, want nil", err)
+			}
+			if reader == nil {
+				t.Error("newMetricReader() returned a nil reader")
+			}
+
+			if tt.wantHTTPHandler && handler == nil {
+				t.Error("newMetricReader() returned a nil http.Handler, want a prometheus scrape handler")
+			}
+			if !tt.wantHTTPHandler && handler != nil {
+				t.Errorf("newMetricReader() returned a non-nil http.Handler for exporter %q, want nil", tt.exporter)
+			}
+		})
+	}
+}
+
+func TestIsHTTPProtocol(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol string
+		want     bool
+	}{
+		{"empty defaults to grpc", "", false},
+		{"grpc", "grpc", false},
+		{"http/protobuf", "http/protobuf", true},
+		{"http", "http", true},
+		{"case insensitive", "HTTP/PROTOBUF", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isHTTPProtocol(Config{OTLPProtocol: tt.protocol}); got != tt.want {
+				t.Errorf("isHTTPProtocol(%q) = %v, want %v", tt.protocol, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewResource_SetsServiceNameAndVersion(t *testing.T) {
+	res := newResource("my-service", "1.2.3")
+
+	attrs := res.Attributes()
+	got := make(map[string]string, len(attrs))
+	for _, a := range attrs {
+		got[string(a.Key)] = a.Value.AsString()
+	}
+
+	if got["service.name"] != "my-service" {
+		t.Errorf("service.name = %q, want %q", got["service.name"], "my-service")
+	}
+	if got["service.version"] != "1.2.3" {
+		t.Errorf("service.version = %q, want %q", got["service.version"], "1.2.3")
+	}
+}
@@ -0,0 +1,221 @@
+package elvenotel
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	otelmetric "go.opentelemetry.io/otel/metric"
+)
+
+// MetricsRegistry pre-declares OTel instruments from a set of Metric
+// definitions and constructs each one exactly once, so callers that need
+// the same instrument on every request (e.g. the Gin middlewares) look it
+// up instead of re-creating it.
+type MetricsRegistry struct {
+	meter otelmetric.Meter
+
+	mu                  sync.Mutex
+	int64Histograms     map[string]otelmetric.Int64Histogram
+	float64Histograms   map[string]otelmetric.Float64Histogram
+	int64UpDownCounters map[string]otelmetric.Int64UpDownCounter
+	int64Counters       map[string]otelmetric.Int64Counter
+	int64Gauges         map[string]otelmetric.Int64ObservableGauge
+}
+
+// NewMetricsRegistry constructs the instrument for every metric in metrics
+// up front, failing if two metrics share a Name.
+func NewMetricsRegistry(meter otelmetric.Meter, metrics []Metric) (*MetricsRegistry, error) {
+	registry := &MetricsRegistry{
+		meter:               meter,
+		int64Histograms:     map[string]otelmetric.Int64Histogram{},
+		float64Histograms:   map[string]otelmetric.Float64Histogram{},
+		int64UpDownCounters: map[string]otelmetric.Int64UpDownCounter{},
+		int64Counters:       map[string]otelmetric.Int64Counter{},
+		int64Gauges:         map[string]otelmetric.Int64ObservableGauge{},
+	}
+
+	seen := make(map[string]struct{}, len(metrics))
+	for _, m := range metrics {
+		if _, ok := seen[m.Name]; ok {
+			return nil, fmt.Errorf("metric %q registered more than once", m.Name)
+		}
+		seen[m.Name] = struct{}{}
+
+		if _, err := registry.build(m); err != nil {
+			return nil, err
+		}
+	}
+
+	return registry, nil
+}
+
+// build returns the cached instrument for m, creating it on first use.
+func (r *MetricsRegistry) build(m Metric) (interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch m.Kind {
+	case MetricKindInt64Histogram:
+		if h, ok := r.int64Histograms[m.Name]; ok {
+			return h, nil
+		}
+
+		h, err := r.meter.Int64Histogram(m.Name, otelmetric.WithDescription(m.Description), otelmetric.WithUnit(m.Unit))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create histogram %q: %w", m.Name, err)
+		}
+		r.int64Histograms[m.Name] = h
+
+		return h, nil
+
+	case MetricKindFloat64Histogram:
+		if h, ok := r.float64Histograms[m.Name]; ok {
+			return h, nil
+		}
+
+		opts := []otelmetric.Float64HistogramOption{
+			otelmetric.WithDescription(m.Description),
+			otelmetric.WithUnit(m.Unit),
+		}
+		if len(m.Boundaries) > 0 {
+			opts = append(opts, otelmetric.WithExplicitBucketBoundaries(m.Boundaries...))
+		}
+
+		h, err := r.meter.Float64Histogram(m.Name, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create histogram %q: %w", m.Name, err)
+		}
+		r.float64Histograms[m.Name] = h
+
+		return h, nil
+
+	case MetricKindInt64UpDownCounter:
+		if c, ok := r.int64UpDownCounters[m.Name]; ok {
+			return c, nil
+		}
+
+		c, err := r.meter.Int64UpDownCounter(m.Name, otelmetric.WithDescription(m.Description), otelmetric.WithUnit(m.Unit))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create up-down counter %q: %w", m.Name, err)
+		}
+		r.int64UpDownCounters[m.Name] = c
+
+		return c, nil
+
+	case MetricKindInt64Counter:
+		if c, ok := r.int64Counters[m.Name]; ok {
+			return c, nil
+		}
+
+		c, err := r.meter.Int64Counter(m.Name, otelmetric.WithDescription(m.Description), otelmetric.WithUnit(m.Unit))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create counter %q: %w", m.Name, err)
+		}
+		r.int64Counters[m.Name] = c
+
+		return c, nil
+
+	case MetricKindInt64Gauge:
+		if g, ok := r.int64Gauges[m.Name]; ok {
+			return g, nil
+		}
+
+		g, err := r.meter.Int64ObservableGauge(m.Name, otelmetric.WithDescription(m.Description), otelmetric.WithUnit(m.Unit))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gauge %q: %w", m.Name, err)
+		}
+		r.int64Gauges[m.Name] = g
+
+		return g, nil
+
+	default:
+		return nil, fmt.Errorf("metric %q has no registered kind", m.Name)
+	}
+}
+
+// Histogram returns the cached int64 histogram registered under name.
+func (r *MetricsRegistry) Histogram(name string) (otelmetric.Int64Histogram, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.int64Histograms[name]
+	return h, ok
+}
+
+// Float64Histogram returns the cached float64 histogram registered under name.
+func (r *MetricsRegistry) Float64Histogram(name string) (otelmetric.Float64Histogram, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.float64Histograms[name]
+	return h, ok
+}
+
+// UpDownCounter returns the cached up/down counter registered under name.
+func (r *MetricsRegistry) UpDownCounter(name string) (otelmetric.Int64UpDownCounter, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.int64UpDownCounters[name]
+	return c, ok
+}
+
+// Counter returns the cached counter registered under name.
+func (r *MetricsRegistry) Counter(name string) (otelmetric.Int64Counter, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.int64Counters[name]
+	return c, ok
+}
+
+// Gauge returns the cached observable gauge registered under name.
+func (r *MetricsRegistry) Gauge(name string) (otelmetric.Int64ObservableGauge, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	g, ok := r.int64Gauges[name]
+	return g, ok
+}
+
+// GetOrCreateInt64Histogram returns the cached histogram for m.Name,
+// creating and caching it on first use.
+func (r *MetricsRegistry) GetOrCreateInt64Histogram(m Metric) (otelmetric.Int64Histogram, error) {
+	m.Kind = MetricKindInt64Histogram
+
+	inst, err := r.build(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return inst.(otelmetric.Int64Histogram), nil
+}
+
+// GetOrCreateInt64UpDownCounter returns the cached up/down counter for
+// m.Name, creating and caching it on first use.
+func (r *MetricsRegistry) GetOrCreateInt64UpDownCounter(m Metric) (otelmetric.Int64UpDownCounter, error) {
+	m.Kind = MetricKindInt64UpDownCounter
+
+	inst, err := r.build(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return inst.(otelmetric.Int64UpDownCounter), nil
+}
+
+// RegisterCallback wires fn into the meter's collection cycle for the
+// Int64ObservableGauge registered under metricName. An observable gauge
+// never reports a value on its own; the OTel SDK only calls Observe on it
+// from inside a callback registered this way, so callers that declared a
+// MetricKindInt64Gauge metric must call RegisterCallback for it before any
+// data point is produced.
+func (r *MetricsRegistry) RegisterCallback(metricName string, fn func(context.Context, otelmetric.Observer) error) (otelmetric.Registration, error) {
+	gauge, ok := r.Gauge(metricName)
+	if !ok {
+		return nil, fmt.Errorf("no gauge registered under %q", metricName)
+	}
+
+	reg, err := r.meter.RegisterCallback(fn, gauge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register callback for gauge %q: %w", metricName, err)
+	}
+
+	return reg, nil
+}
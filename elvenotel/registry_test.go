@@ -0,0 +1,62 @@
+package elvenotel
+
+import (
+	"context"
+	"testing"
+
+	otelmetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func TestNewMetricsRegistry_RejectsDuplicateNames(t *testing.T) {
+	meter := noop.NewMeterProvider().Meter("test")
+
+	_, err := NewMetricsRegistry(meter, []Metric{
+		{Name: "dup", Kind: MetricKindInt64Counter},
+		{Name: "dup", Kind: MetricKindInt64Counter},
+	})
+	if err == nil {
+		t.Fatal("NewMetricsRegistry() with two metrics sharing a Name returned a nil error, want one rejecting the duplicate")
+	}
+}
+
+func TestMetricsRegistry_GetOrCreateCachesTheInstrument(t *testing.T) {
+	meter := noop.NewMeterProvider().Meter("test")
+	registry, err := NewMetricsRegistry(meter, nil)
+	if err != nil {
+		t.Fatalf("NewMetricsRegistry() error = %v", err)
+	}
+
+	if _, err := registry.GetOrCreateInt64UpDownCounter(Metric{Name: "in_flight"}); err != nil {
+		t.Fatalf("GetOrCreateInt64UpDownCounter() error = %v", err)
+	}
+
+	if _, ok := registry.UpDownCounter("in_flight"); !ok {
+		t.Fatal("UpDownCounter(\"in_flight\") not found after GetOrCreateInt64UpDownCounter created it")
+	}
+}
+
+func TestMetricsRegistry_RegisterCallback_ErrorsWithoutAGauge(t *testing.T) {
+	meter := noop.NewMeterProvider().Meter("test")
+	registry, err := NewMetricsRegistry(meter, nil)
+	if err != nil {
+		t.Fatalf("NewMetricsRegistry() error = %v", err)
+	}
+
+	_, err = registry.RegisterCallback("not_a_gauge", func(context.Context, otelmetric.Observer) error { return nil })
+	if err == nil {
+		t.Fatal("RegisterCallback() for a name with no registered gauge returned a nil error")
+	}
+}
+
+func TestMetricsRegistry_RegisterCallback_SucceedsForARegisteredGauge(t *testing.T) {
+	meter := noop.NewMeterProvider().Meter("test")
+	registry, err := NewMetricsRegistry(meter, []Metric{{Name: "queue_depth", Kind: MetricKindInt64Gauge}})
+	if err != nil {
+		t.Fatalf("NewMetricsRegistry() error = %v", err)
+	}
+
+	if _, err := registry.RegisterCallback("queue_depth", func(context.Context, otelmetric.Observer) error { return nil }); err != nil {
+		t.Fatalf("RegisterCallback() error = %v", err)
+	}
+}
@@ -0,0 +1,179 @@
+package elvenotel
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// samplerFromConfig builds the sdktrace.Sampler selected by
+// cfg.OTELTracesSampler, following the OTEL_TRACES_SAMPLER names from the
+// OTel spec (always_on, always_off, traceidratio, parentbased_always_on,
+// parentbased_always_off, parentbased_traceidratio), plus two extensions:
+// rate_limiting and parentbased_rate_limiting, a token-bucket sampler
+// capping spans/sec instead of sampling by ratio.
+func samplerFromConfig(cfg Config) (sdktrace.Sampler, error) {
+	switch strings.ToLower(cfg.OTELTracesSampler) {
+	case "always_on":
+		return sdktrace.AlwaysSample(), nil
+	case "always_off":
+		return sdktrace.NeverSample(), nil
+	case "traceidratio":
+		ratio, err := parseSamplerRatio(cfg.OTELTracesSamplerArg)
+		if err != nil {
+			return nil, err
+		}
+		return newTraceIDRatioSampler(ratio), nil
+	case "", "parentbased_always_on":
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	case "parentbased_always_off":
+		return sdktrace.ParentBased(sdktrace.NeverSample()), nil
+	case "parentbased_traceidratio":
+		ratio, err := parseSamplerRatio(cfg.OTELTracesSamplerArg)
+		if err != nil {
+			return nil, err
+		}
+		return sdktrace.ParentBased(newTraceIDRatioSampler(ratio)), nil
+	case "rate_limiting":
+		rate, err := parseSamplerRate(cfg.OTELTracesSamplerArg)
+		if err != nil {
+			return nil, err
+		}
+		return newRateLimitingSampler(rate), nil
+	case "parentbased_rate_limiting":
+		rate, err := parseSamplerRate(cfg.OTELTracesSamplerArg)
+		if err != nil {
+			return nil, err
+		}
+		return sdktrace.ParentBased(newRateLimitingSampler(rate)), nil
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_TRACES_SAMPLER %q", cfg.OTELTracesSampler)
+	}
+}
+
+// parseSamplerRatio parses OTEL_TRACES_SAMPLER_ARG for the traceidratio
+// samplers, defaulting to 1.0 (sample everything) when arg is empty and
+// clamping the result to [0, 1].
+func parseSamplerRatio(arg string) (float64, error) {
+	if arg == "" {
+		return 1, nil
+	}
+
+	ratio, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid OTEL_TRACES_SAMPLER_ARG %q for a ratio-based sampler: %w", arg, err)
+	}
+
+	return math.Min(1, math.Max(0, ratio)), nil
+}
+
+// parseSamplerRate parses OTEL_TRACES_SAMPLER_ARG for the rate_limiting
+// samplers as spans/sec, defaulting to 100 when arg is empty.
+func parseSamplerRate(arg string) (float64, error) {
+	if arg == "" {
+		return 100, nil
+	}
+
+	rate, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid OTEL_TRACES_SAMPLER_ARG %q for the rate_limiting sampler: %w", arg, err)
+	}
+	if rate < 0 {
+		return 0, fmt.Errorf("OTEL_TRACES_SAMPLER_ARG %q for the rate_limiting sampler must not be negative", arg)
+	}
+
+	return rate, nil
+}
+
+// traceIDRatioSampler samples a deterministic fraction of traces by
+// comparing the low 8 bytes of the trace ID, read as a big-endian uint64,
+// against a threshold derived from ratio. Using the trace ID instead of a
+// random draw means every span in a trace makes the same decision.
+type traceIDRatioSampler struct {
+	ratio     float64
+	threshold uint64
+}
+
+func newTraceIDRatioSampler(ratio float64) *traceIDRatioSampler {
+	return &traceIDRatioSampler{
+		ratio:     ratio,
+		threshold: uint64(ratio * float64(math.MaxUint64)),
+	}
+}
+
+func (s *traceIDRatioSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	psc := oteltrace.SpanContextFromContext(p.ParentContext)
+
+	decision := sdktrace.Drop
+	if s.ratio >= 1 || binary.BigEndian.Uint64(p.TraceID[8:16]) < s.threshold {
+		decision = sdktrace.RecordAndSample
+	}
+
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: psc.TraceState(),
+	}
+}
+
+func (s *traceIDRatioSampler) Description() string {
+	return fmt.Sprintf("TraceIDRatioBased{%g}", s.ratio)
+}
+
+// rateLimitingSampler is a token-bucket sampler: it samples at most rate
+// spans per second, with a bucket capacity equal to rate and a refill rate
+// of rate tokens/sec, so short bursts up to the capacity are allowed but
+// the long-run average never exceeds rate.
+type rateLimitingSampler struct {
+	rate float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimitingSampler(ratePerSecond float64) *rateLimitingSampler {
+	return &rateLimitingSampler{rate: ratePerSecond, tokens: ratePerSecond, last: time.Now()}
+}
+
+func (s *rateLimitingSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	psc := oteltrace.SpanContextFromContext(p.ParentContext)
+
+	decision := sdktrace.Drop
+	if s.allow() {
+		decision = sdktrace.RecordAndSample
+	}
+
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: psc.TraceState(),
+	}
+}
+
+func (s *rateLimitingSampler) Description() string {
+	return fmt.Sprintf("RateLimitingSampler{%g spans/sec}", s.rate)
+}
+
+// allow reports whether the bucket currently has a token to spend,
+// refilling it for elapsed time since the last call before checking.
+func (s *rateLimitingSampler) allow() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.tokens = math.Min(s.rate, s.tokens+now.Sub(s.last).Seconds()*s.rate)
+	s.last = now
+
+	if s.tokens < 1 {
+		return false
+	}
+
+	s.tokens--
+	return true
+}
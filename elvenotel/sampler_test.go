@@ -0,0 +1,135 @@
+package elvenotel
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceIDRatioSampler_HashesLow8Bytes(t *testing.T) {
+	sampler := newTraceIDRatioSampler(0.5)
+
+	tests := []struct {
+		name     string
+		traceID  oteltrace.TraceID
+		wantSamp bool
+	}{
+		// Low 8 bytes just below the 0.5 threshold (2^63): sampled.
+		{"below threshold", oteltrace.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, true},
+		// Low 8 bytes exactly at the threshold: not sampled (strict <).
+		{"at threshold", oteltrace.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0x80, 0, 0, 0, 0, 0, 0, 0}, false},
+		// High 8 bytes shouldn't affect the decision at all.
+		{"high bytes ignored", oteltrace.TraceID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0, 0, 0, 0, 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := sampler.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: context.Background(),
+				TraceID:       tt.traceID,
+			})
+
+			gotSamp := result.Decision == sdktrace.RecordAndSample
+			if gotSamp != tt.wantSamp {
+				t.Fatalf("ShouldSample(%x) = %v, want sampled=%v", tt.traceID, result.Decision, tt.wantSamp)
+			}
+		})
+	}
+}
+
+func TestTraceIDRatioSampler_ExtremeRatios(t *testing.T) {
+	anyTraceID := oteltrace.TraceID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+	allOn := newTraceIDRatioSampler(1)
+	if result := allOn.ShouldSample(sdktrace.SamplingParameters{ParentContext: context.Background(), TraceID: anyTraceID}); result.Decision != sdktrace.RecordAndSample {
+		t.Fatalf("ratio=1 sampler dropped a trace, want always sampled")
+	}
+
+	allOff := newTraceIDRatioSampler(0)
+	if result := allOff.ShouldSample(sdktrace.SamplingParameters{ParentContext: context.Background(), TraceID: anyTraceID}); result.Decision != sdktrace.Drop {
+		t.Fatalf("ratio=0 sampler sampled a trace, want always dropped")
+	}
+}
+
+func TestRateLimitingSampler_TokenBucketCapacity(t *testing.T) {
+	sampler := newRateLimitingSampler(2)
+
+	params := sdktrace.SamplingParameters{ParentContext: context.Background(), TraceID: oteltrace.TraceID{1}}
+
+	allowed := 0
+	for i := 0; i < 5; i++ {
+		if sampler.ShouldSample(params).Decision == sdktrace.RecordAndSample {
+			allowed++
+		}
+	}
+
+	if allowed != 2 {
+		t.Fatalf("bucket with capacity 2 allowed %d of 5 back-to-back requests, want 2", allowed)
+	}
+}
+
+func TestParentBasedRateLimiting_RespectsRemoteParentDecision(t *testing.T) {
+	// Rate of 0 means the local rate limiter never allows a sample on its own.
+	sampler := sdktrace.ParentBased(newRateLimitingSampler(0))
+
+	sampledParent := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    oteltrace.TraceID{1},
+		SpanID:     oteltrace.SpanID{1},
+		TraceFlags: oteltrace.FlagsSampled,
+		Remote:     true,
+	})
+	ctx := oteltrace.ContextWithSpanContext(context.Background(), sampledParent)
+
+	result := sampler.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: ctx,
+		TraceID:       oteltrace.TraceID{2},
+	})
+	if result.Decision != sdktrace.RecordAndSample {
+		t.Fatalf("ParentBased sampler ignored a sampled remote parent: got %v", result.Decision)
+	}
+
+	unsampledParent := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID: oteltrace.TraceID{1},
+		SpanID:  oteltrace.SpanID{1},
+		Remote:  true,
+	})
+	ctx = oteltrace.ContextWithSpanContext(context.Background(), unsampledParent)
+
+	result = sampler.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: ctx,
+		TraceID:       oteltrace.TraceID{2},
+	})
+	if result.Decision != sdktrace.Drop {
+		t.Fatalf("ParentBased sampler ignored an unsampled remote parent: got %v", result.Decision)
+	}
+}
+
+func TestSamplerFromConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"always_on", Config{OTELTracesSampler: "always_on"}, false},
+		{"always_off", Config{OTELTracesSampler: "always_off"}, false},
+		{"traceidratio", Config{OTELTracesSampler: "traceidratio", OTELTracesSamplerArg: "0.1"}, false},
+		{"parentbased_always_on default", Config{}, false},
+		{"parentbased_always_off", Config{OTELTracesSampler: "parentbased_always_off"}, false},
+		{"parentbased_traceidratio", Config{OTELTracesSampler: "parentbased_traceidratio", OTELTracesSamplerArg: "0.5"}, false},
+		{"rate_limiting", Config{OTELTracesSampler: "rate_limiting", OTELTracesSamplerArg: "50"}, false},
+		{"parentbased_rate_limiting", Config{OTELTracesSampler: "parentbased_rate_limiting"}, false},
+		{"invalid ratio arg", Config{OTELTracesSampler: "traceidratio", OTELTracesSamplerArg: "not-a-number"}, true},
+		{"unknown sampler", Config{OTELTracesSampler: "bogus"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := samplerFromConfig(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("samplerFromConfig(%+v) error = %v, wantErr %v", tt.cfg, err, tt.wantErr)
+			}
+		})
+	}
+}